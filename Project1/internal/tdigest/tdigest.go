@@ -0,0 +1,123 @@
+// Package tdigest implements a streaming approximation of the quantiles
+// of a distribution, based on Ted Dunning's merging t-digest. It lets the
+// scheduler report p50/p90/p99/p99.9 of waiting and turnaround time
+// without retaining every sample in memory.
+package tdigest
+
+import (
+	"math"
+	"sort"
+)
+
+// centroid is a single cluster in the digest: the mean of the samples it
+// represents and their total weight.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a merging t-digest. The zero value is not usable; use New.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	unmerged    []centroid
+	totalWeight float64
+}
+
+// New returns a TDigest with the given compression factor. Higher
+// compression gives more accurate quantiles at the cost of more memory;
+// 100 is a good default, giving roughly 1% error at extreme quantiles.
+func New(compression float64) *TDigest {
+	return &TDigest{compression: compression}
+}
+
+// bufferThreshold is the point, relative to the compression factor, at
+// which unmerged samples are folded into the sorted centroid list.
+const bufferThreshold = 5
+
+// Add records a sample with the given weight (use 1 for a single
+// observation).
+func (t *TDigest) Add(x, w float64) {
+	t.unmerged = append(t.unmerged, centroid{mean: x, weight: w})
+	t.totalWeight += w
+	if float64(len(t.unmerged)) >= bufferThreshold*t.compression {
+		t.compress()
+	}
+}
+
+// scale is Dunning's k-scale function, which bounds how much weight a
+// centroid may hold depending on where it sits in the cumulative
+// distribution: centroids near the median may absorb much more weight
+// than centroids near the tails, which is what gives the tails their
+// accuracy.
+func (t *TDigest) scale(q float64) float64 {
+	return (t.compression / (2 * math.Pi)) * math.Asin(2*q-1)
+}
+
+// compress merges the unmerged buffer into the sorted centroid list in a
+// single left-to-right pass.
+func (t *TDigest) compress() {
+	if len(t.unmerged) == 0 {
+		return
+	}
+	all := append(t.centroids, t.unmerged...)
+	t.unmerged = nil
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 0, len(all))
+	var q, total float64
+	total = t.totalWeight
+	for _, c := range all {
+		if len(merged) == 0 {
+			merged = append(merged, c)
+			continue
+		}
+		last := &merged[len(merged)-1]
+		limit := t.scale((q+last.weight+c.weight)/total) - t.scale(q/total)
+		if limit <= 1 {
+			last.mean = (last.mean*last.weight + c.mean*c.weight) / (last.weight + c.weight)
+			last.weight += c.weight
+		} else {
+			q += last.weight
+			merged = append(merged, c)
+		}
+	}
+	t.centroids = merged
+}
+
+// Quantile returns an estimate of the value at quantile q (0 <= q <= 1).
+func (t *TDigest) Quantile(q float64) float64 {
+	t.compress()
+	if len(t.centroids) == 0 {
+		return 0
+	}
+	if len(t.centroids) == 1 {
+		return t.centroids[0].mean
+	}
+
+	target := q * t.totalWeight
+	var cumulative float64
+	for i, c := range t.centroids {
+		next := cumulative + c.weight
+		if target <= next || i == len(t.centroids)-1 {
+			if i == 0 {
+				return c.mean
+			}
+			prev := t.centroids[i-1]
+			prevCumulative := cumulative - prev.weight/2
+			currCumulative := cumulative + c.weight/2
+			if currCumulative == prevCumulative {
+				return c.mean
+			}
+			frac := (target - prevCumulative) / (currCumulative - prevCumulative)
+			return prev.mean + frac*(c.mean-prev.mean)
+		}
+		cumulative = next
+	}
+	return t.centroids[len(t.centroids)-1].mean
+}
+
+// Count returns the total weight of all samples added so far.
+func (t *TDigest) Count() float64 {
+	return t.totalWeight
+}
@@ -0,0 +1,41 @@
+package tdigest
+
+import "testing"
+
+// TestQuantileDistinctTails feeds enough uniform samples that, if
+// compress collapsed centroids without bound, every quantile would
+// converge on the same value (the overall mean). A correct digest keeps
+// p50/p90/p99/p99.9 distinct and roughly in line with the true uniform
+// quantiles.
+func TestQuantileDistinctTails(t *testing.T) {
+	td := New(100)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		td.Add(float64(i), 1)
+	}
+
+	p50 := td.Quantile(0.5)
+	p90 := td.Quantile(0.9)
+	p99 := td.Quantile(0.99)
+	p999 := td.Quantile(0.999)
+
+	if p50 >= p90 || p90 >= p99 || p99 >= p999 {
+		t.Fatalf("expected strictly increasing quantiles, got p50=%v p90=%v p99=%v p999=%v", p50, p90, p99, p999)
+	}
+
+	wantP50, wantP90, wantP99 := float64(n)*0.5, float64(n)*0.9, float64(n)*0.99
+	if tol := float64(n) * 0.02; abs(p50-wantP50) > tol {
+		t.Errorf("p50 = %v, want near %v", p50, wantP50)
+	} else if abs(p90-wantP90) > tol {
+		t.Errorf("p90 = %v, want near %v", p90, wantP90)
+	} else if abs(p99-wantP99) > tol {
+		t.Errorf("p99 = %v, want near %v", p99, wantP99)
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
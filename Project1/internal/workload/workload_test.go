@@ -0,0 +1,85 @@
+package workload
+
+import "testing"
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig("poisson:lambda=0.5,burst=exp:5,n=10000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Lambda != 0.5 || cfg.N != 10000 || cfg.Seed != 1 {
+		t.Fatalf("ParseConfig() = %+v, want Lambda=0.5 N=10000 Seed=1", cfg)
+	}
+	exp, ok := cfg.Burst.(ExpBurst)
+	if !ok || exp.Mean != 5 {
+		t.Fatalf("cfg.Burst = %+v, want ExpBurst{Mean: 5}", cfg.Burst)
+	}
+}
+
+func TestParseConfigAllFields(t *testing.T) {
+	cfg, err := ParseConfig("poisson:lambda=0.5,burst=bimodal:2|50|0.8,n=10000,priority=10,seed=7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxPriority != 10 || cfg.Seed != 7 {
+		t.Fatalf("ParseConfig() = %+v, want MaxPriority=10 Seed=7", cfg)
+	}
+	bimodal, ok := cfg.Burst.(BimodalBurst)
+	if !ok || bimodal != (BimodalBurst{Short: 2, Long: 50, ShortWeight: 0.8}) {
+		t.Fatalf("cfg.Burst = %+v, want BimodalBurst{2, 50, 0.8}", cfg.Burst)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []string{
+		"lambda=0.5,burst=exp:5,n=10000",            // missing "poisson:" prefix
+		"uniform:lambda=0.5,n=10",                   // unknown arrival process
+		"poisson:lambda=0.5,n=10000",                // missing burst
+		"poisson:lambda=0,burst=exp:5,n=10000",      // non-positive lambda
+		"poisson:lambda=0.5,burst=exp:5,n=0",        // non-positive n
+		"poisson:lambda=0.5,burst=exp:5,n=abc",      // non-numeric field
+		"poisson:lambda=0.5,burst=exp:5,huh=1,n=10", // unknown field
+	}
+	for _, in := range tests {
+		if _, err := ParseConfig(in); err == nil {
+			t.Errorf("ParseConfig(%q): expected error, got none", in)
+		}
+	}
+}
+
+func TestParseBurst(t *testing.T) {
+	tests := []struct {
+		in   string
+		want BurstDist
+	}{
+		{"exp:5", ExpBurst{Mean: 5}},
+		{"bimodal:2|50|0.8", BimodalBurst{Short: 2, Long: 50, ShortWeight: 0.8}},
+		{"pareto:1|2.5", ParetoBurst{Scale: 1, Shape: 2.5}},
+	}
+	for _, tt := range tests {
+		got, err := parseBurst(tt.in)
+		if err != nil {
+			t.Errorf("parseBurst(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseBurst(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseBurstErrors(t *testing.T) {
+	tests := []string{
+		"exp",            // missing ':'
+		"exp:5|1",        // wrong parameter count
+		"bimodal:2|50",   // wrong parameter count
+		"pareto:1",       // wrong parameter count
+		"exp:notanumber", // non-numeric parameter
+		"gaussian:1|2",   // unknown distribution
+	}
+	for _, in := range tests {
+		if _, err := parseBurst(in); err == nil {
+			t.Errorf("parseBurst(%q): expected error, got none", in)
+		}
+	}
+}
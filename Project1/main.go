@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -12,38 +13,133 @@ import (
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
+
+	"project1/internal/tdigest"
+	"project1/internal/workload"
 )
 
 func main() {
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
-	if err != nil {
-		log.Fatal(err)
+	format := flag.String("format", "text", "output format: text|json|ndjson")
+	generate := flag.String("generate", "", "generate a synthetic workload instead of reading a CSV, "+
+		"e.g. poisson:lambda=0.5,burst=exp:5,n=10000")
+	ncpu := flag.String("ncpu", "", "comma-separated CPU counts to additionally run SRTF under via SMPSchedule, "+
+		"e.g. -ncpu=2,4 to compare SRTF on 2 and 4 CPUs against the single-CPU results")
+	flag.Parse()
+
+	var processes []Process
+	if *generate != "" {
+		cfg, err := workload.ParseConfig(*generate)
+		if err != nil {
+			log.Fatal(err)
+		}
+		processes = toProcesses(workload.Generate(cfg))
+	} else {
+		f, closeFile, err := openProcessingFile(flag.Args()...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer closeFile()
+
+		processes, err = loadProcesses(f)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
-	defer closeFile()
 
-	// Load and parse processes
-	processes, err := loadProcesses(f)
+	reporter, err := newReporter(*format, os.Stdout)
 	if err != nil {
 		log.Fatal(err)
 	}
+	r := NewCollectingReporter(reporter)
 
 	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+	FCFSSchedule(r, "First-come, first-serve", cloneProcesses(processes))
+
+	SJFSchedule(r, "Shortest-job-first", cloneProcesses(processes))
+
+	SJFPrioritySchedule(r, "Priority", cloneProcesses(processes))
+
+	RRSchedule(r, "Round-robin", cloneProcesses(processes))
 
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
+	MLFQSchedule(r, "Multi-level feedback queue", cloneProcesses(processes))
 
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
+	if *ncpu != "" {
+		cpuCounts, err := parseNCPU(*ncpu)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, n := range cpuCounts {
+			SMPSchedule(r, fmt.Sprintf("Shortest-job-first (%d CPUs)", n), cloneProcesses(processes), n, srtfKey)
+		}
+	}
+
+	// A generated workload is a benchmarking run: follow it with a table
+	// comparing every algorithm's mean/p90/p99 wait, turnaround and
+	// throughput side by side.
+	if *generate != "" {
+		outputComparison(os.Stdout, r.Results)
+	}
+}
+
+// toProcesses adapts a generated workload trace, which knows nothing
+// about the scheduler's own Process type, into one.
+func toProcesses(generated []workload.Process) []Process {
+	processes := make([]Process, len(generated))
+	for i, g := range generated {
+		processes[i] = Process{
+			ProcessID:     g.ID,
+			BurstDuration: g.Burst,
+			Burst:         g.Burst,
+			ArrivalTime:   g.Arrival,
+			Priority:      g.Priority,
+		}
+	}
+	return processes
+}
 
-	RRSchedule(os.Stdout, "Round-robin", processes)
+// cloneProcesses returns a copy of processes so a scheduler that mutates
+// its input in place (RRSchedule reduces BurstDuration and re-sorts as it
+// runs) can't corrupt what the next scheduler in main sees.
+func cloneProcesses(processes []Process) []Process {
+	return append([]Process(nil), processes...)
+}
+
+// parseNCPU parses the -ncpu flag, a comma-separated list of positive CPU
+// counts, e.g. "2,4".
+func parseNCPU(s string) ([]int, error) {
+	parts := strings.Split(s, ",")
+	counts := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w: invalid -ncpu value %q", ErrInvalidArgs, part)
+		}
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
+// newReporter builds the Reporter for the given -format flag value.
+func newReporter(format string, w io.Writer) (Reporter, error) {
+	switch format {
+	case "text":
+		return NewTextReporter(w), nil
+	case "json":
+		return NewJSONReporter(w), nil
+	case "ndjson":
+		return NewNDJSONReporter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, format)
+	}
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
+	if len(args) != 1 {
 		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
 	}
 	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
+	f, err := os.Open(args[0])
 	if err != nil {
 		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
 	}
@@ -63,11 +159,13 @@ type (
 		Burst         int64 //original burst duration
 		ArrivalTime   int64
 		Priority      int64
+		IOPattern     []BurstSegment // optional cpu/io burst pattern, e.g. "cpu:4,io:2,cpu:3"
 	}
 	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		CPU   int   `json:"cpu,omitempty"` // which CPU slot ran this slice; 0 outside SMPSchedule
 	}
 )
 
@@ -75,46 +173,50 @@ type (
 
 // Scheduling functions outputs a schedule of processes
 // in a GANTT chart and a table of timing given:
-// • an output writer
+// • a Reporter to render results through
 // • a title for the chart
 // • a slice of processes
 
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
+func FCFSSchedule(r Reporter, title string, processes []Process) {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		rows            = make([]ProcessResult, len(processes))
+		waitTD          = tdigest.New(100)
+		turnaroundTD    = tdigest.New(100)
 	)
+	r.Begin(title)
 	for i := range processes {
 		if processes[i].ArrivalTime > 0 {
 			waitingTime = serviceTime - processes[i].ArrivalTime
 		}
 		totalWait += float64(waitingTime)
+		waitTD.Add(float64(waitingTime), 1)
 
 		start := waitingTime + processes[i].ArrivalTime
 
 		turnaround := processes[i].BurstDuration + waitingTime
 		totalTurnaround += float64(turnaround)
+		turnaroundTD.Add(float64(turnaround), 1)
 
 		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
 		lastCompletion = float64(completion)
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+		rows[i] = ProcessResult{
+			PID:        processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      processes[i].BurstDuration,
+			Arrival:    processes[i].ArrivalTime,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			Completion: completion,
 		}
 		serviceTime += processes[i].BurstDuration
 
-		gantt = append(gantt, TimeSlice{
+		r.GanttSlice(TimeSlice{
 			PID:   processes[i].ProcessID,
 			Start: start,
 			Stop:  serviceTime,
@@ -122,295 +224,36 @@ func FCFSSchedule(w io.Writer, title string, processes []Process) {
 	}
 
 	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	r.End(ScheduleResult{
+		Algorithm:             title,
+		Rows:                  rows,
+		AvgWait:               totalWait / count,
+		AvgTurnaround:         totalTurnaround / count,
+		Throughput:            count / lastCompletion,
+		WaitPercentiles:       percentileStats(waitTD),
+		TurnaroundPercentiles: percentileStats(turnaroundTD),
+	})
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		compareNext     int64
-		compare         = true
-		onHold          []Process
-		count           = float64(len(processes))
-	)
-	for i := range processes {
-
-		//Current process waiting time
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		//Current total waiting
-		totalWait += float64(waitingTime)
-
-		//Process start time
-		var start = waitingTime + processes[i].ArrivalTime
-
-		//Compare to other processes that have not arrived
-		if compare {
-			for c := i + 1; c < len(processes); c++ {
-				compareNext = start + processes[i].BurstDuration - processes[c].ArrivalTime
-				//If next process will arrive before current process completion
-				if compareNext > 0 {
-					//If next process has a shorter job
-					if processes[i].BurstDuration > processes[c].BurstDuration {
-
-						//Add current process to onHold list
-						processes[i].BurstDuration -= (processes[c].ArrivalTime - start)
-						onHold = append(onHold, processes[i])
-						sort.Sort(ByBurst(onHold))
-
-						//Gantt Schedule
-						serviceTime += (processes[c].ArrivalTime - start)
-						gantt = append(gantt, TimeSlice{
-							PID:   processes[i].ProcessID,
-							Start: start,
-							Stop:  serviceTime,
-						})
-
-						//Delete current process from processes list
-						processes = append(processes[:i], processes[i+1:]...)
-						c--
-
-						//New current process waiting time
-						if processes[i].ArrivalTime > 0 {
-							waitingTime = serviceTime - processes[i].ArrivalTime
-						}
-
-						//New current total waiting
-						totalWait += float64(waitingTime)
-
-						//New process start time
-						start = waitingTime + processes[i].ArrivalTime
-
-					} else { //If next process does not have a shorter job
-
-						//Add next process to onHold list
-						onHold = append(onHold, processes[c])
-						sort.Sort(ByBurst(onHold))
-
-						//Delete next process from processes list
-						processes = append(processes[:c], processes[c+1:]...)
-						c--
-					}
-				} else {
-					break
-				}
-			}
-		}
-
-		//Gantt Schedule
-		serviceTime += processes[i].BurstDuration
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
-
-		//Schedule Table
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].Burst),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-
-		//Prepend preemptive/waiting process
-		if compare && len(onHold) > 0 && i != (len(processes)-1) {
-			if onHold[0].ProcessID != processes[i+1].ProcessID {
-				processes = Insert(processes, i+1, onHold[0])
-			}
-			if len(onHold) > 1 {
-				onHold = append(onHold[:0], onHold[1:]...)
-			} else {
-				onHold = nil
-			}
-		}
-
-		// Processes left to run
-		if i == (len(processes) - 1) {
-			processes = append(processes, onHold...)
-			onHold = nil
-			compare = false
-		}
-	}
-
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		compareNext     int64
-		compare         = true
-		onHold          []Process
-		count           = float64(len(processes))
-	)
-	sort.Sort(ByArrival(processes))
-	for i := range processes {
-
-		//Current process waiting time
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		//Current total waiting
-		totalWait += float64(waitingTime)
+// SJFSchedule and SJFPrioritySchedule (shortest-remaining-time-first and
+// priority-with-aging, both genuinely preemptive) live in srtf.go, which
+// shares a single event-driven simulation engine between the two.
 
-		//Process start time
-		var start = waitingTime + processes[i].ArrivalTime
-
-		//Compare to other processes that have not arrived
-		if compare {
-			for c := i + 1; c < len(processes); c++ {
-				compareNext = start + processes[i].BurstDuration - processes[c].ArrivalTime
-				//If next process will arrive before current process completion
-				if compareNext > 0 {
-					//If next process has higher priority
-					if processes[i].Priority < processes[c].Priority {
-
-						//Add current process to onHold list
-						processes[i].BurstDuration -= (processes[c].ArrivalTime - start)
-						onHold = append(onHold, processes[i])
-						sort.Sort(ByPriority(onHold))
-
-						//Gantt Schedule
-						serviceTime += (processes[c].ArrivalTime - start)
-						gantt = append(gantt, TimeSlice{
-							PID:   processes[i].ProcessID,
-							Start: start,
-							Stop:  serviceTime,
-						})
-
-						//Delete current process from processes list
-						processes = append(processes[:i], processes[i+1:]...)
-						c--
-
-						//New current process waiting time
-						if processes[i].ArrivalTime > 0 {
-							waitingTime = serviceTime - processes[i].ArrivalTime
-						}
-
-						//New current total waiting
-						totalWait += float64(waitingTime)
-
-						//New process start time
-						start = waitingTime + processes[i].ArrivalTime
-
-					} else { //If next process does not higher priority
-
-						//Add next process to onHold list
-						onHold = append(onHold, processes[c])
-						sort.Sort(ByPriority(onHold))
-
-						//Delete next process from processes list
-						processes = append(processes[:c], processes[c+1:]...)
-						c--
-					}
-				} else {
-					break
-				}
-			}
-		}
-
-		//Gantt Schedule
-		serviceTime += processes[i].BurstDuration
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
-
-		//Schedule Table
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].Burst),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-
-		//Prepend preemptive/waiting process
-		if compare && len(onHold) > 0 && i != (len(processes)-1) {
-			if onHold[0].ProcessID != processes[i+1].ProcessID {
-				processes = Insert(processes, i+1, onHold[0])
-			}
-			if len(onHold) > 1 {
-				onHold = append(onHold[:0], onHold[1:]...)
-			} else {
-				onHold = nil
-			}
-		}
-
-		// Processes left to run
-		if i == (len(processes) - 1) {
-			processes = append(processes, onHold...)
-			onHold = nil
-			compare = false
-		}
-	}
-
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-func RRSchedule(w io.Writer, title string, processes []Process) {
+func RRSchedule(r Reporter, title string, processes []Process) {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		rows            = make([]ProcessResult, len(processes))
 		count           = float64(len(processes))
 		quantum         = int64(3)
 		scheduleCount   = 0
+		waitTD          = tdigest.New(100)
+		turnaroundTD    = tdigest.New(100)
 	)
+	r.Begin(title)
 	sort.Sort(ByArrival(processes))
 	for i := 0; i < len(processes); i++ {
 
@@ -420,13 +263,14 @@ func RRSchedule(w io.Writer, title string, processes []Process) {
 		}
 		//Current total waiting
 		totalWait += float64(waitingTime)
+		waitTD.Add(float64(waitingTime), 1)
 
 		//Process start time
 		var start = waitingTime + processes[i].ArrivalTime
 
 		//Gantt Schedule
 		serviceTime += quantum
-		gantt = append(gantt, TimeSlice{
+		r.GanttSlice(TimeSlice{
 			PID:   processes[i].ProcessID,
 			Start: start,
 			Stop:  serviceTime,
@@ -443,30 +287,33 @@ func RRSchedule(w io.Writer, title string, processes []Process) {
 			//Schedule Table
 			turnaround := processes[i].Burst + waitingTime
 			totalTurnaround += float64(turnaround)
+			turnaroundTD.Add(float64(turnaround), 1)
 
 			completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
 			lastCompletion = float64(completion)
 
-			schedule[scheduleCount] = []string{
-				fmt.Sprint(processes[i].ProcessID),
-				fmt.Sprint(processes[i].Priority),
-				fmt.Sprint(processes[i].Burst),
-				fmt.Sprint(processes[i].ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
+			rows[scheduleCount] = ProcessResult{
+				PID:        processes[i].ProcessID,
+				Priority:   processes[i].Priority,
+				Burst:      processes[i].Burst,
+				Arrival:    processes[i].ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				Completion: completion,
 			}
 			scheduleCount++
 		}
 	}
 
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	r.End(ScheduleResult{
+		Algorithm:             title,
+		Rows:                  rows,
+		AvgWait:               totalWait / count,
+		AvgTurnaround:         totalTurnaround / count,
+		Throughput:            count / lastCompletion,
+		WaitPercentiles:       percentileStats(waitTD),
+		TurnaroundPercentiles: percentileStats(turnaroundTD),
+	})
 }
 
 //endregion
@@ -479,8 +326,39 @@ func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
+// outputGantt prints the Gantt chart. Schedulers that only ever run on one
+// CPU leave TimeSlice.CPU at zero, which renders as a single unlabeled
+// chart exactly as before; SMPSchedule fills in CPU per slice, which
+// renders as one labeled row per CPU from 0 up to the highest CPU any
+// slice ran on. A run whose slices all ran on CPU 0 renders as the single
+// unlabeled chart.
 func outputGantt(w io.Writer, gantt []TimeSlice) {
 	_, _ = fmt.Fprintln(w, "Gantt schedule")
+
+	maxCPU := 0
+	for _, ts := range gantt {
+		if ts.CPU > maxCPU {
+			maxCPU = ts.CPU
+		}
+	}
+	if maxCPU == 0 {
+		outputGanttRow(w, gantt)
+		return
+	}
+
+	for cpu := 0; cpu <= maxCPU; cpu++ {
+		var row []TimeSlice
+		for _, ts := range gantt {
+			if ts.CPU == cpu {
+				row = append(row, ts)
+			}
+		}
+		_, _ = fmt.Fprintf(w, "CPU %d:\n", cpu)
+		outputGanttRow(w, row)
+	}
+}
+
+func outputGanttRow(w io.Writer, gantt []TimeSlice) {
 	_, _ = fmt.Fprint(w, "|")
 	for i := range gantt {
 		pid := fmt.Sprint(gantt[i].PID)
@@ -509,6 +387,54 @@ func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput f
 	table.Render()
 }
 
+// outputPercentiles prints p50/p90/p99/p99.9 of waiting and turnaround time,
+// estimated from a streaming t-digest rather than the full sample set.
+func outputPercentiles(w io.Writer, wait, turnaround PercentileStats) {
+	_, _ = fmt.Fprintln(w, "Tail latency (t-digest estimate)")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Metric", "p50", "p90", "p99", "p99.9"})
+	table.Append([]string{"Wait",
+		fmt.Sprintf("%.2f", wait.P50),
+		fmt.Sprintf("%.2f", wait.P90),
+		fmt.Sprintf("%.2f", wait.P99),
+		fmt.Sprintf("%.2f", wait.P999),
+	})
+	table.Append([]string{"Turnaround",
+		fmt.Sprintf("%.2f", turnaround.P50),
+		fmt.Sprintf("%.2f", turnaround.P90),
+		fmt.Sprintf("%.2f", turnaround.P99),
+		fmt.Sprintf("%.2f", turnaround.P999),
+	})
+	table.Render()
+}
+
+// outputComparison prints a table comparing mean/p90/p99 wait and
+// turnaround, and throughput, across every algorithm that ran over the
+// same trace — the summary a generated benchmarking workload is for.
+func outputComparison(w io.Writer, results []ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "Algorithm comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{
+		"Algorithm",
+		"Wait (mean)", "Wait (p90)", "Wait (p99)",
+		"Turnaround (mean)", "Turnaround (p90)", "Turnaround (p99)",
+		"Throughput",
+	})
+	for _, res := range results {
+		table.Append([]string{
+			res.Algorithm,
+			fmt.Sprintf("%.2f", res.AvgWait),
+			fmt.Sprintf("%.2f", res.WaitPercentiles.P90),
+			fmt.Sprintf("%.2f", res.WaitPercentiles.P99),
+			fmt.Sprintf("%.2f", res.AvgTurnaround),
+			fmt.Sprintf("%.2f", res.TurnaroundPercentiles.P90),
+			fmt.Sprintf("%.2f", res.TurnaroundPercentiles.P99),
+			fmt.Sprintf("%.2f/t", res.Throughput),
+		})
+	}
+	table.Render()
+}
+
 //endregion
 
 //region Loading processes.
@@ -527,9 +453,16 @@ func loadProcesses(r io.Reader) ([]Process, error) {
 		processes[i].BurstDuration = mustStrToInt(rows[i][1])
 		processes[i].Burst = mustStrToInt(rows[i][1])
 		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
+		if len(rows[i]) >= 4 {
 			processes[i].Priority = mustStrToInt(rows[i][3])
 		}
+		if len(rows[i]) == 5 {
+			segments, err := parseIOPattern(rows[i][4])
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d", err, i)
+			}
+			processes[i].IOPattern = segments
+		}
 	}
 
 	sort.Sort(ByArrival(processes))
@@ -543,30 +476,6 @@ func (a ByArrival) Len() int           { return len(a) }
 func (a ByArrival) Less(i, j int) bool { return a[i].ArrivalTime < a[j].ArrivalTime }
 func (a ByArrival) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
-// ByBurst implements sort.Interface based on the BurstDuration field
-type ByBurst []Process
-
-func (a ByBurst) Len() int           { return len(a) }
-func (a ByBurst) Less(i, j int) bool { return a[i].BurstDuration < a[j].BurstDuration }
-func (a ByBurst) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-
-// ByPriority implements sort.Interface based on the Priority field
-type ByPriority []Process
-
-func (a ByPriority) Len() int           { return len(a) }
-func (a ByPriority) Less(i, j int) bool { return a[i].Priority < a[j].Priority }
-func (a ByPriority) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-
-// Insert Process
-func Insert(a []Process, index int, value Process) []Process {
-	if len(a) == index { // nil or empty slice or after last element
-		return append(a, value)
-	}
-	a = append(a[:index+1], a[index:]...) // index < len(a)
-	a[index] = value
-	return a
-}
-
 func mustStrToInt(s string) int64 {
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestCloneProcessesIsolatesCallers guards against the bug where
+// RRSchedule's in-place mutation of its processes argument (shrinking
+// BurstDuration, re-sorting) leaked into whichever scheduler ran after
+// it on the same slice. Each scheduler in main now gets its own copy via
+// cloneProcesses; this checks that copy is actually independent.
+func TestCloneProcessesIsolatesCallers(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10, Burst: 10, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 5, Burst: 5, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 7, Burst: 7, ArrivalTime: 2},
+		{ProcessID: 4, BurstDuration: 6, Burst: 6, ArrivalTime: 3},
+	}
+
+	RRSchedule(NewCollectingReporter(NewTextReporter(io.Discard)), "Round-robin", cloneProcesses(processes))
+
+	if processes[0].BurstDuration != 10 {
+		t.Fatalf("RRSchedule mutated the original slice through its cloned argument: processes[0].BurstDuration = %d, want 10", processes[0].BurstDuration)
+	}
+
+	r := NewCollectingReporter(NewTextReporter(io.Discard))
+	FCFSSchedule(r, "First-come, first-serve", cloneProcesses(processes))
+
+	wantCompletion := []int64{10, 15, 22, 28}
+	for i, row := range r.Results[0].Rows {
+		if row.Completion != wantCompletion[i] {
+			t.Fatalf("row %d: Completion = %d, want %d (burst truncated by a prior scheduler's mutation?)", i, row.Completion, wantCompletion[i])
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"project1/internal/tdigest"
+)
+
+// BurstSegment is one leg of a process's execution: either a run of CPU
+// work or a run of I/O wait. A process with no I/O pattern is modeled as
+// a single CPU segment equal to its BurstDuration.
+type BurstSegment struct {
+	CPU      bool
+	Duration int64
+}
+
+// parseIOPattern parses the optional fifth CSV column, e.g.
+// "cpu:4,io:2,cpu:3", into an alternating list of CPU/I/O segments.
+func parseIOPattern(s string) ([]BurstSegment, error) {
+	parts := strings.Split(s, ",")
+	segments := make([]BurstSegment, 0, len(parts))
+	for _, part := range parts {
+		kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: malformed io pattern segment %q", ErrInvalidArgs, part)
+		}
+		d, err := strconv.ParseInt(kv[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v: invalid duration in io pattern segment %q", err, part)
+		}
+		switch kv[0] {
+		case "cpu":
+			segments = append(segments, BurstSegment{CPU: true, Duration: d})
+		case "io":
+			segments = append(segments, BurstSegment{CPU: false, Duration: d})
+		default:
+			return nil, fmt.Errorf("%w: unknown io pattern kind %q", ErrInvalidArgs, kv[0])
+		}
+	}
+	return segments, nil
+}
+
+// MLFQConfig configures a multi-level feedback queue: the time quantum of
+// each priority level, from highest to lowest, and the interval at which
+// every process is boosted back to the top queue to avoid starvation.
+type MLFQConfig struct {
+	Quantums      []int64
+	BoostInterval int64
+}
+
+// DefaultMLFQConfig returns the standard 3-queue configuration used when
+// MLFQSchedule is called without a custom MLFQConfig.
+func DefaultMLFQConfig() MLFQConfig {
+	return MLFQConfig{Quantums: []int64{2, 4, 8}, BoostInterval: 50}
+}
+
+// mlfqProcess tracks the runtime state of a process as it moves through
+// the feedback queues.
+type mlfqProcess struct {
+	process    Process
+	segments   []BurstSegment
+	segIdx     int
+	consumed   int64
+	level      int
+	readyAt    int64
+	wakeAt     int64
+	waiting    int64
+	completion int64
+}
+
+// MLFQSchedule schedules processes using a multi-level feedback queue with
+// DefaultMLFQConfig. See MLFQScheduleWithConfig to customize the number of
+// queues, their quanta, or the priority boost interval.
+func MLFQSchedule(r Reporter, title string, processes []Process) {
+	MLFQScheduleWithConfig(r, title, processes, DefaultMLFQConfig())
+}
+
+// MLFQScheduleWithConfig schedules processes using a multi-level feedback
+// queue. A process that consumes its entire quantum without blocking on
+// I/O is demoted to the next-lower queue; a process that voluntarily
+// yields for I/O keeps its level; every cfg.BoostInterval time units all
+// processes are boosted back to the top queue.
+func MLFQScheduleWithConfig(r Reporter, title string, processes []Process, cfg MLFQConfig) {
+	numQueues := len(cfg.Quantums)
+	procs := make([]*mlfqProcess, len(processes))
+	for i := range processes {
+		segments := processes[i].IOPattern
+		if len(segments) == 0 {
+			segments = []BurstSegment{{CPU: true, Duration: processes[i].BurstDuration}}
+		}
+		procs[i] = &mlfqProcess{process: processes[i], segments: segments}
+	}
+
+	queues := make([][]*mlfqProcess, numQueues)
+	var blocked []*mlfqProcess
+	r.Begin(title)
+
+	var (
+		t         int64
+		arrived   int
+		completed int
+		count     = float64(len(procs))
+		nextBoost = cfg.BoostInterval
+	)
+
+	for completed < len(procs) {
+		for arrived < len(procs) && procs[arrived].process.ArrivalTime <= t {
+			procs[arrived].readyAt = t
+			queues[0] = append(queues[0], procs[arrived])
+			arrived++
+		}
+
+		if len(blocked) > 0 {
+			remaining := blocked[:0]
+			for _, p := range blocked {
+				if p.wakeAt <= t {
+					p.readyAt = t
+					queues[p.level] = append(queues[p.level], p)
+				} else {
+					remaining = append(remaining, p)
+				}
+			}
+			blocked = remaining
+		}
+
+		if cfg.BoostInterval > 0 && t >= nextBoost {
+			for lvl := 1; lvl < numQueues; lvl++ {
+				for _, p := range queues[lvl] {
+					p.level = 0
+					p.readyAt = t
+				}
+				queues[0] = append(queues[0], queues[lvl]...)
+				queues[lvl] = nil
+			}
+			for _, p := range blocked {
+				p.level = 0
+			}
+			for nextBoost <= t {
+				nextBoost += cfg.BoostInterval
+			}
+		}
+
+		level := -1
+		for lvl := range queues {
+			if len(queues[lvl]) > 0 {
+				level = lvl
+				break
+			}
+		}
+		if level == -1 {
+			next := nextMLFQEvent(t, procs, arrived, blocked, cfg)
+			t = next
+			continue
+		}
+
+		p := queues[level][0]
+		queues[level] = queues[level][1:]
+		p.waiting += t - p.readyAt
+
+		quantum := cfg.Quantums[level]
+		seg := p.segments[p.segIdx]
+		run := seg.Duration - p.consumed
+		if run > quantum {
+			run = quantum
+		}
+
+		r.GanttSlice(TimeSlice{PID: p.process.ProcessID, Start: t, Stop: t + run})
+		t += run
+		p.consumed += run
+
+		switch {
+		case p.consumed == seg.Duration:
+			p.segIdx++
+			p.consumed = 0
+			if p.segIdx >= len(p.segments) {
+				p.completion = t
+				completed++
+				continue
+			}
+			if !p.segments[p.segIdx].CPU {
+				p.wakeAt = t + p.segments[p.segIdx].Duration
+				blocked = append(blocked, p)
+				continue
+			}
+			p.readyAt = t
+			queues[p.level] = append(queues[p.level], p)
+		default:
+			if p.level < numQueues-1 {
+				p.level++
+			}
+			p.readyAt = t
+			queues[p.level] = append(queues[p.level], p)
+		}
+	}
+
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		rows            = make([]ProcessResult, len(procs))
+		waitTD          = tdigest.New(100)
+		turnaroundTD    = tdigest.New(100)
+	)
+	for i, p := range procs {
+		turnaround := p.completion - p.process.ArrivalTime
+		totalWait += float64(p.waiting)
+		totalTurnaround += float64(turnaround)
+		waitTD.Add(float64(p.waiting), 1)
+		turnaroundTD.Add(float64(turnaround), 1)
+		if float64(p.completion) > lastCompletion {
+			lastCompletion = float64(p.completion)
+		}
+		finalQueue := p.level
+		rows[i] = ProcessResult{
+			PID:        p.process.ProcessID,
+			Priority:   p.process.Priority,
+			Burst:      p.process.Burst,
+			Arrival:    p.process.ArrivalTime,
+			Wait:       p.waiting,
+			Turnaround: turnaround,
+			Completion: p.completion,
+			FinalQueue: &finalQueue,
+		}
+	}
+
+	r.End(ScheduleResult{
+		Algorithm:             title,
+		Rows:                  rows,
+		AvgWait:               totalWait / count,
+		AvgTurnaround:         totalTurnaround / count,
+		Throughput:            count / lastCompletion,
+		WaitPercentiles:       percentileStats(waitTD),
+		TurnaroundPercentiles: percentileStats(turnaroundTD),
+	})
+}
+
+// nextMLFQEvent returns the next time at which something becomes
+// schedulable: an arrival, an I/O completion, or a priority boost.
+func nextMLFQEvent(t int64, procs []*mlfqProcess, arrived int, blocked []*mlfqProcess, cfg MLFQConfig) int64 {
+	next := int64(-1)
+	if arrived < len(procs) {
+		next = procs[arrived].process.ArrivalTime
+	}
+	for _, p := range blocked {
+		if next == -1 || p.wakeAt < next {
+			next = p.wakeAt
+		}
+	}
+	if cfg.BoostInterval > 0 {
+		boostAt := (t/cfg.BoostInterval + 1) * cfg.BoostInterval
+		if next == -1 || boostAt < next {
+			next = boostAt
+		}
+	}
+	if next == -1 {
+		return t
+	}
+	return next
+}
+
+// outputMLFQSchedule prints the MLFQ schedule table, extended with a
+// FinalQueue column showing which priority level each process finished in.
+func outputMLFQSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit", "FinalQueue"})
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "",
+		fmt.Sprintf("Average\n%.2f", wait),
+		fmt.Sprintf("Average\n%.2f", turnaround),
+		fmt.Sprintf("Throughput\n%.2f/t", throughput),
+		""})
+	table.Render()
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseIOPattern(t *testing.T) {
+	got, err := parseIOPattern("cpu:4,io:2,cpu:3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []BurstSegment{
+		{CPU: true, Duration: 4},
+		{CPU: false, Duration: 2},
+		{CPU: true, Duration: 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseIOPattern() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseIOPattern() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestParseIOPatternErrors(t *testing.T) {
+	tests := []string{
+		"cpu4",         // missing ':'
+		"cpu:four",     // non-numeric duration
+		"net:5",        // unknown kind
+		"cpu:4,io:bad", // malformed later segment
+	}
+	for _, in := range tests {
+		if _, err := parseIOPattern(in); err == nil {
+			t.Errorf("parseIOPattern(%q): expected error, got none", in)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestBoostFiresBetweenExactTicks checks that the priority boost fires
+// once t passes BoostInterval, even when no run ends exactly on a
+// multiple of it (quantum sizes rarely divide the interval evenly). A
+// single CPU-bound process is demoted out of the top queue almost
+// immediately; if the boost only fired on t%BoostInterval==0 it would be
+// skipped forever here, and the process would finish in the bottom
+// queue instead of back at the top.
+func TestBoostFiresBetweenExactTicks(t *testing.T) {
+	cfg := MLFQConfig{Quantums: []int64{3, 5}, BoostInterval: 10}
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 14, Burst: 14, ArrivalTime: 0},
+	}
+
+	r := NewCollectingReporter(NewTextReporter(io.Discard))
+	MLFQScheduleWithConfig(r, "MLFQ", processes, cfg)
+
+	row := r.Results[0].Rows[0]
+	if row.FinalQueue == nil || *row.FinalQueue != 0 {
+		t.Fatalf("expected process to be boosted back to queue 0 before finishing, got FinalQueue=%v", row.FinalQueue)
+	}
+}
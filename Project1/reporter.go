@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"project1/internal/tdigest"
+)
+
+// ProcessResult is a single process's row in a finished schedule.
+type ProcessResult struct {
+	PID        int64 `json:"pid"`
+	Priority   int64 `json:"priority"`
+	Burst      int64 `json:"burst"`
+	Arrival    int64 `json:"arrival"`
+	Wait       int64 `json:"wait"`
+	Turnaround int64 `json:"turnaround"`
+	Completion int64 `json:"completion"`
+	FinalQueue *int  `json:"finalQueue,omitempty"`
+}
+
+// PercentileStats holds the tail-latency percentiles reported for a metric.
+type PercentileStats struct {
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	P999 float64 `json:"p99_9"`
+}
+
+// percentileStats reads the p50/p90/p99/p99.9 quantiles out of a t-digest.
+func percentileStats(td *tdigest.TDigest) PercentileStats {
+	return PercentileStats{
+		P50:  td.Quantile(0.5),
+		P90:  td.Quantile(0.9),
+		P99:  td.Quantile(0.99),
+		P999: td.Quantile(0.999),
+	}
+}
+
+// ScheduleResult is the complete, structured output of a scheduling run,
+// independent of how it gets rendered.
+type ScheduleResult struct {
+	Algorithm             string          `json:"algorithm"`
+	Rows                  []ProcessResult `json:"rows"`
+	Gantt                 []TimeSlice     `json:"gantt"`
+	AvgWait               float64         `json:"avgWait"`
+	AvgTurnaround         float64         `json:"avgTurnaround"`
+	Throughput            float64         `json:"throughput"`
+	WaitPercentiles       PercentileStats `json:"waitPercentiles"`
+	TurnaroundPercentiles PercentileStats `json:"turnaroundPercentiles"`
+}
+
+// Reporter receives a scheduling run as it happens: Begin marks the start
+// of an algorithm, GanttSlice is called once per context switch as the
+// simulation progresses, and End delivers the final aggregate result.
+type Reporter interface {
+	Begin(algorithm string)
+	GanttSlice(slice TimeSlice)
+	End(result ScheduleResult)
+}
+
+// TextReporter renders results as the module's original ASCII title,
+// Gantt chart, schedule table and percentile table.
+type TextReporter struct {
+	w     io.Writer
+	title string
+	gantt []TimeSlice
+}
+
+// NewTextReporter returns a Reporter that writes human-readable tables to w.
+func NewTextReporter(w io.Writer) *TextReporter {
+	return &TextReporter{w: w}
+}
+
+func (t *TextReporter) Begin(algorithm string) {
+	t.title = algorithm
+	t.gantt = nil
+}
+
+func (t *TextReporter) GanttSlice(slice TimeSlice) {
+	t.gantt = append(t.gantt, slice)
+}
+
+func (t *TextReporter) End(result ScheduleResult) {
+	outputTitle(t.w, t.title)
+	outputGantt(t.w, t.gantt)
+
+	hasFinalQueue := false
+	for _, row := range result.Rows {
+		if row.FinalQueue != nil {
+			hasFinalQueue = true
+			break
+		}
+	}
+
+	if hasFinalQueue {
+		rows := make([][]string, len(result.Rows))
+		for i, row := range result.Rows {
+			finalQueue := 0
+			if row.FinalQueue != nil {
+				finalQueue = *row.FinalQueue
+			}
+			rows[i] = []string{
+				fmt.Sprint(row.PID),
+				fmt.Sprint(row.Priority),
+				fmt.Sprint(row.Burst),
+				fmt.Sprint(row.Arrival),
+				fmt.Sprint(row.Wait),
+				fmt.Sprint(row.Turnaround),
+				fmt.Sprint(row.Completion),
+				fmt.Sprint(finalQueue),
+			}
+		}
+		outputMLFQSchedule(t.w, rows, result.AvgWait, result.AvgTurnaround, result.Throughput)
+	} else {
+		rows := make([][]string, len(result.Rows))
+		for i, row := range result.Rows {
+			rows[i] = []string{
+				fmt.Sprint(row.PID),
+				fmt.Sprint(row.Priority),
+				fmt.Sprint(row.Burst),
+				fmt.Sprint(row.Arrival),
+				fmt.Sprint(row.Wait),
+				fmt.Sprint(row.Turnaround),
+				fmt.Sprint(row.Completion),
+			}
+		}
+		outputSchedule(t.w, rows, result.AvgWait, result.AvgTurnaround, result.Throughput)
+	}
+
+	outputPercentiles(t.w, result.WaitPercentiles, result.TurnaroundPercentiles)
+}
+
+// JSONReporter renders one JSON object per algorithm, holding the full
+// result: rows, Gantt timeline, and aggregate stats.
+type JSONReporter struct {
+	w     io.Writer
+	algo  string
+	gantt []TimeSlice
+}
+
+// NewJSONReporter returns a Reporter that writes one indented JSON object
+// per algorithm to w.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: w}
+}
+
+func (j *JSONReporter) Begin(algorithm string) {
+	j.algo = algorithm
+	j.gantt = nil
+}
+
+func (j *JSONReporter) GanttSlice(slice TimeSlice) {
+	j.gantt = append(j.gantt, slice)
+}
+
+func (j *JSONReporter) End(result ScheduleResult) {
+	result.Algorithm = j.algo
+	result.Gantt = j.gantt
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+// NDJSONReporter streams newline-delimited JSON events as the simulation
+// progresses: one "gantt" event per context switch, followed by a single
+// "result" event once the algorithm finishes.
+type NDJSONReporter struct {
+	w    io.Writer
+	algo string
+}
+
+// NewNDJSONReporter returns a Reporter that streams NDJSON events to w.
+func NewNDJSONReporter(w io.Writer) *NDJSONReporter {
+	return &NDJSONReporter{w: w}
+}
+
+type ndjsonGanttEvent struct {
+	Event     string `json:"event"`
+	Algorithm string `json:"algorithm"`
+	PID       int64  `json:"pid"`
+	Start     int64  `json:"start"`
+	Stop      int64  `json:"stop"`
+}
+
+type ndjsonResultEvent struct {
+	Event string `json:"event"`
+	ScheduleResult
+}
+
+func (n *NDJSONReporter) Begin(algorithm string) {
+	n.algo = algorithm
+}
+
+func (n *NDJSONReporter) GanttSlice(slice TimeSlice) {
+	n.emit(ndjsonGanttEvent{Event: "gantt", Algorithm: n.algo, PID: slice.PID, Start: slice.Start, Stop: slice.Stop})
+}
+
+func (n *NDJSONReporter) End(result ScheduleResult) {
+	result.Algorithm = n.algo
+	n.emit(ndjsonResultEvent{Event: "result", ScheduleResult: result})
+}
+
+func (n *NDJSONReporter) emit(v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_, _ = n.w.Write(b)
+	_, _ = n.w.Write([]byte("\n"))
+}
+
+// CollectingReporter wraps another Reporter, forwarding every call to it
+// unchanged while also retaining each algorithm's ScheduleResult, so the
+// caller can build a cross-algorithm comparison once every algorithm has
+// run.
+type CollectingReporter struct {
+	Reporter
+	Results []ScheduleResult
+}
+
+// NewCollectingReporter returns a CollectingReporter that forwards to inner.
+func NewCollectingReporter(inner Reporter) *CollectingReporter {
+	return &CollectingReporter{Reporter: inner}
+}
+
+func (c *CollectingReporter) End(result ScheduleResult) {
+	c.Results = append(c.Results, result)
+	c.Reporter.End(result)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+
+	"project1/internal/tdigest"
+)
+
+// SMPSchedule dispatches processes across ncpu parallel CPUs sharing a
+// single ready queue: at every arrival and completion the ncpu best-ranked
+// ready (or running) processes are the ones running, so a running process
+// is preempted at such an instant when some ready process ranks strictly
+// better than it under key; keys are not compared in between. It reuses the simProcess/event machinery from srtf.go; key
+// selects SRTF (srtfKey) or priority-with-aging (agingPriorityKey), the
+// same as the single-CPU simulate engine.
+func SMPSchedule(r Reporter, title string, processes []Process, ncpu int, key keyFunc) {
+	if ncpu < 1 {
+		ncpu = 1
+	}
+
+	arrivals := &eventHeap{}
+	heap.Init(arrivals)
+	for i := range processes {
+		heap.Push(arrivals, &event{time: processes[i].ArrivalTime, proc: &simProcess{
+			process:   processes[i],
+			orderIdx:  i,
+			remaining: processes[i].BurstDuration,
+		}})
+	}
+
+	r.Begin(title)
+
+	var (
+		now          int64
+		ready        []*simProcess
+		running      = make([]*simProcess, ncpu)
+		sliceStart   = make([]int64, ncpu)
+		completed    int
+		rows         = make([]ProcessResult, len(processes))
+		totalWait    float64
+		totalTurn    float64
+		lastComplete float64
+		waitTD       = tdigest.New(100)
+		turnaroundTD = tdigest.New(100)
+		count        = float64(len(processes))
+	)
+
+	closeSlice := func(cpu int, stop int64) {
+		if running[cpu] != nil && stop > sliceStart[cpu] {
+			r.GanttSlice(TimeSlice{PID: running[cpu].process.ProcessID, Start: sliceStart[cpu], Stop: stop, CPU: cpu})
+		}
+	}
+
+	for completed < len(processes) {
+		for arrivals.Len() > 0 && (*arrivals)[0].time <= now {
+			e := heap.Pop(arrivals).(*event)
+			ready = append(ready, e.proc)
+		}
+
+		idle := len(ready) == 0
+		if idle {
+			for _, p := range running {
+				if p != nil {
+					idle = false
+					break
+				}
+			}
+		}
+		if idle {
+			now = (*arrivals)[0].time
+			continue
+		}
+
+		// Rank everyone ready or running; the best ncpu of them are the
+		// ones that should be running. Running processes are listed first
+		// and the sort is stable, so a tie keeps them on their CPU.
+		var candidates []*simProcess
+		for _, p := range running {
+			if p != nil {
+				candidates = append(candidates, p)
+			}
+		}
+		candidates = append(candidates, ready...)
+		sort.SliceStable(candidates, func(i, j int) bool { return key(candidates[i], now) < key(candidates[j], now) })
+
+		n := ncpu
+		if len(candidates) < n {
+			n = len(candidates)
+		}
+		selected := candidates[:n]
+		assigned := make([]bool, n)
+
+		// Keep CPUs already running a selected process; evict the rest
+		// back into the ready queue.
+		for cpu, p := range running {
+			if p == nil {
+				continue
+			}
+			kept := false
+			for i, s := range selected {
+				if s == p && !assigned[i] {
+					assigned[i] = true
+					kept = true
+					break
+				}
+			}
+			if !kept {
+				closeSlice(cpu, now)
+				ready = append(ready, p)
+				running[cpu] = nil
+			}
+		}
+
+		// Drop selected processes out of the ready queue.
+		filtered := ready[:0]
+		for _, p := range ready {
+			inSelected := false
+			for _, s := range selected {
+				if s == p {
+					inSelected = true
+					break
+				}
+			}
+			if !inSelected {
+				filtered = append(filtered, p)
+			}
+		}
+		ready = filtered
+
+		// Fill the CPUs that are now empty with the remaining selected
+		// processes, opening a new Gantt slice on each.
+		si := 0
+		for cpu := 0; cpu < ncpu; cpu++ {
+			if running[cpu] != nil {
+				continue
+			}
+			for si < n && assigned[si] {
+				si++
+			}
+			if si >= n {
+				break
+			}
+			running[cpu] = selected[si]
+			sliceStart[cpu] = now
+			assigned[si] = true
+			si++
+		}
+
+		// Advance to the next interesting instant: the next arrival, or
+		// whichever running process finishes first.
+		next := int64(-1)
+		if arrivals.Len() > 0 {
+			next = (*arrivals)[0].time
+		}
+		for _, p := range running {
+			if p == nil {
+				continue
+			}
+			finish := now + p.remaining
+			if next == -1 || finish < next {
+				next = finish
+			}
+		}
+
+		elapsed := next - now
+		for _, p := range running {
+			if p != nil {
+				p.remaining -= elapsed
+			}
+		}
+		for _, p := range ready {
+			p.waiting += elapsed
+		}
+		now = next
+
+		for cpu, p := range running {
+			if p == nil || p.remaining > 0 {
+				continue
+			}
+			closeSlice(cpu, now)
+			completion := now
+			turnaround := completion - p.process.ArrivalTime
+			totalWait += float64(p.waiting)
+			totalTurn += float64(turnaround)
+			waitTD.Add(float64(p.waiting), 1)
+			turnaroundTD.Add(float64(turnaround), 1)
+			if float64(completion) > lastComplete {
+				lastComplete = float64(completion)
+			}
+			rows[p.orderIdx] = ProcessResult{
+				PID:        p.process.ProcessID,
+				Priority:   p.process.Priority,
+				Burst:      p.process.Burst,
+				Arrival:    p.process.ArrivalTime,
+				Wait:       p.waiting,
+				Turnaround: turnaround,
+				Completion: completion,
+			}
+			completed++
+			running[cpu] = nil
+		}
+	}
+
+	r.End(ScheduleResult{
+		Algorithm:             title,
+		Rows:                  rows,
+		AvgWait:               totalWait / count,
+		AvgTurnaround:         totalTurn / count,
+		Throughput:            count / lastComplete,
+		WaitPercentiles:       percentileStats(waitTD),
+		TurnaroundPercentiles: percentileStats(turnaroundTD),
+	})
+}
@@ -0,0 +1,88 @@
+package main
+
+import "testing"
+
+// recordingReporter is a minimal Reporter that just keeps every
+// GanttSlice and the final ScheduleResult, for tests that need to
+// inspect the schedule a scheduler actually produced rather than
+// rendered output.
+type recordingReporter struct {
+	gantt  []TimeSlice
+	result ScheduleResult
+}
+
+func (r *recordingReporter) Begin(string)            {}
+func (r *recordingReporter) GanttSlice(ts TimeSlice) { r.gantt = append(r.gantt, ts) }
+func (r *recordingReporter) End(res ScheduleResult)  { r.result = res }
+
+// TestSMPScheduleRunsConcurrently checks the actual behavior SMPSchedule
+// exists to deliver: two equally-ranked processes arriving together on
+// ncpu=2 run at the same time on different CPUs instead of serially, so
+// both finish at the burst length rather than one waiting on the other.
+func TestSMPScheduleRunsConcurrently(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Burst: 5, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 5, Burst: 5, ArrivalTime: 0},
+	}
+
+	r := &recordingReporter{}
+	SMPSchedule(r, "SRTF (2 CPUs)", processes, 2, srtfKey)
+
+	for _, row := range r.result.Rows {
+		if row.Wait != 0 {
+			t.Errorf("process %d: Wait = %d, want 0 (should run immediately alongside the other process)", row.PID, row.Wait)
+		}
+		if row.Completion != 5 {
+			t.Errorf("process %d: Completion = %d, want 5", row.PID, row.Completion)
+		}
+	}
+
+	cpusUsed := map[int]bool{}
+	for _, ts := range r.gantt {
+		if ts.Start != 0 || ts.Stop != 5 {
+			t.Errorf("unexpected Gantt slice %+v, want Start=0 Stop=5", ts)
+		}
+		cpusUsed[ts.CPU] = true
+	}
+	if len(cpusUsed) != 2 {
+		t.Fatalf("expected both processes to run concurrently on 2 distinct CPUs, got slices %+v", r.gantt)
+	}
+}
+
+func TestParseNCPU(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{in: "2", want: []int{2}},
+		{in: "2,4", want: []int{2, 4}},
+		{in: " 1 , 8 ", want: []int{1, 8}},
+		{in: "0", wantErr: true},
+		{in: "nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseNCPU(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseNCPU(%q): expected error, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseNCPU(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("parseNCPU(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseNCPU(%q) = %v, want %v", tt.in, got, tt.want)
+				break
+			}
+		}
+	}
+}
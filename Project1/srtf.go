@@ -0,0 +1,211 @@
+package main
+
+import (
+	"container/heap"
+
+	"project1/internal/tdigest"
+)
+
+// simProcess is the runtime state of one process inside the event-driven
+// simulation engine shared by SJFSchedule and SJFPrioritySchedule.
+type simProcess struct {
+	process   Process
+	orderIdx  int
+	remaining int64
+	waiting   int64 // total time spent ready but not running, so far
+}
+
+// keyFunc ranks ready processes: the simulation always runs whichever
+// ready process has the lowest key at time now.
+type keyFunc func(p *simProcess, now int64) float64
+
+// srtfKey implements shortest-remaining-time-first: always run whichever
+// ready process has the least CPU time left.
+func srtfKey(p *simProcess, now int64) float64 {
+	return float64(p.remaining)
+}
+
+// agingRate controls how quickly a waiting process's effective priority
+// improves, so a steady trickle of higher-priority arrivals cannot starve
+// a low-priority process forever.
+const agingRate = 0.1
+
+// agingPriorityKey implements priority scheduling with aging: a process's
+// effective priority improves the longer it has sat ready but not
+// running, so it is eventually able to preempt a process that arrived
+// with better priority. Time spent actually running does not count, or a
+// process that starts early would keep improving its own key forever.
+func agingPriorityKey(p *simProcess, now int64) float64 {
+	return float64(p.process.Priority) - agingRate*float64(p.waiting)
+}
+
+// event is a point in time at which a process not yet in the simulation
+// arrives and becomes ready to run.
+type event struct {
+	time int64
+	proc *simProcess
+}
+
+type eventHeap []*event
+
+func (h eventHeap) Len() int           { return len(h) }
+func (h eventHeap) Less(i, j int) bool { return h[i].time < h[j].time }
+func (h eventHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) { *h = append(*h, x.(*event)) }
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
+
+// simulate runs a single CPU through processes event by event. At every
+// arrival and completion it runs whichever ready process has the lowest
+// key, preempting the running process when a ready process's key is then
+// strictly lower. Keys are not compared between those instants: a ready
+// process whose key drops below the running one's while it waits (as
+// agingPriorityKey's does) takes over at the next arrival, or when the
+// running process completes. It is the shared engine behind SJFSchedule (srtfKey, i.e. SRTF) and
+// SJFPrioritySchedule (agingPriorityKey).
+func simulate(r Reporter, title string, processes []Process, key keyFunc) {
+	arrivals := &eventHeap{}
+	heap.Init(arrivals)
+	for i := range processes {
+		heap.Push(arrivals, &event{time: processes[i].ArrivalTime, proc: &simProcess{
+			process:   processes[i],
+			orderIdx:  i,
+			remaining: processes[i].BurstDuration,
+		}})
+	}
+
+	r.Begin(title)
+
+	var (
+		now          int64
+		ready        []*simProcess
+		running      *simProcess
+		sliceStart   int64
+		completed    int
+		rows         = make([]ProcessResult, len(processes))
+		totalWait    float64
+		totalTurn    float64
+		lastComplete float64
+		waitTD       = tdigest.New(100)
+		turnaroundTD = tdigest.New(100)
+		count        = float64(len(processes))
+	)
+
+	closeSlice := func(stop int64) {
+		if running != nil && stop > sliceStart {
+			r.GanttSlice(TimeSlice{PID: running.process.ProcessID, Start: sliceStart, Stop: stop})
+		}
+	}
+
+	for completed < len(processes) {
+		for arrivals.Len() > 0 && (*arrivals)[0].time <= now {
+			e := heap.Pop(arrivals).(*event)
+			ready = append(ready, e.proc)
+		}
+
+		if running == nil && len(ready) == 0 {
+			now = (*arrivals)[0].time
+			continue
+		}
+
+		// Pick the best candidate among everything ready, including
+		// whichever process is currently running. The running process is
+		// considered first, so a ready process with an equal key does not
+		// preempt it.
+		candidates := ready
+		if running != nil {
+			candidates = append([]*simProcess{running}, ready...)
+		}
+		best := candidates[0]
+		bestKey := key(best, now)
+		for _, p := range candidates[1:] {
+			if k := key(p, now); k < bestKey {
+				best, bestKey = p, k
+			}
+		}
+
+		if best != running {
+			closeSlice(now)
+			if running != nil {
+				ready = append(ready, running)
+			}
+			for i, p := range ready {
+				if p == best {
+					ready = append(ready[:i], ready[i+1:]...)
+					break
+				}
+			}
+			running = best
+			sliceStart = now
+		}
+
+		// Advance to the next interesting instant: either the running
+		// process finishes, or a new arrival could change the decision.
+		next := now + running.remaining
+		if arrivals.Len() > 0 && (*arrivals)[0].time < next {
+			next = (*arrivals)[0].time
+		}
+
+		elapsed := next - now
+		running.remaining -= elapsed
+		for _, p := range ready {
+			p.waiting += elapsed
+		}
+		now = next
+
+		if running.remaining == 0 {
+			closeSlice(now)
+			completion := now
+			turnaround := completion - running.process.ArrivalTime
+			totalWait += float64(running.waiting)
+			totalTurn += float64(turnaround)
+			waitTD.Add(float64(running.waiting), 1)
+			turnaroundTD.Add(float64(turnaround), 1)
+			if float64(completion) > lastComplete {
+				lastComplete = float64(completion)
+			}
+			rows[running.orderIdx] = ProcessResult{
+				PID:        running.process.ProcessID,
+				Priority:   running.process.Priority,
+				Burst:      running.process.Burst,
+				Arrival:    running.process.ArrivalTime,
+				Wait:       running.waiting,
+				Turnaround: turnaround,
+				Completion: completion,
+			}
+			completed++
+			running = nil
+		}
+	}
+
+	r.End(ScheduleResult{
+		Algorithm:             title,
+		Rows:                  rows,
+		AvgWait:               totalWait / count,
+		AvgTurnaround:         totalTurn / count,
+		Throughput:            count / lastComplete,
+		WaitPercentiles:       percentileStats(waitTD),
+		TurnaroundPercentiles: percentileStats(turnaroundTD),
+	})
+}
+
+// SJFSchedule schedules processes by shortest-remaining-time-first,
+// preempting the running process whenever an arrival gives a shorter
+// remaining burst than what is left on the CPU.
+func SJFSchedule(r Reporter, title string, processes []Process) {
+	simulate(r, title, processes, srtfKey)
+}
+
+// SJFPrioritySchedule schedules processes by priority, aging a waiting
+// process's effective priority over time so it cannot be starved forever
+// by a steady stream of higher-priority arrivals.
+func SJFPrioritySchedule(r Reporter, title string, processes []Process) {
+	simulate(r, title, processes, agingPriorityKey)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestAgingPriorityKeyOnlyAgesWhileWaiting checks that a process's
+// effective priority only improves while it sits ready, not while it is
+// running. A long, low-priority process that starts first must not
+// become unpreemptable just by virtue of running for a long time: a
+// higher-priority arrival should preempt it almost immediately.
+func TestAgingPriorityKeyOnlyAgesWhileWaiting(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 1000, Burst: 1000, ArrivalTime: 0, Priority: 5},
+		{ProcessID: 2, BurstDuration: 10, Burst: 10, ArrivalTime: 100, Priority: 1},
+	}
+
+	r := NewCollectingReporter(NewTextReporter(io.Discard))
+	SJFPrioritySchedule(r, "Priority", processes)
+
+	result := r.Results[0]
+	var waitB int64
+	for _, row := range result.Rows {
+		if row.PID == 2 {
+			waitB = row.Wait
+		}
+	}
+
+	if waitB > 5 {
+		t.Fatalf("process 2 (priority 1) waited %d ticks behind process 1 (priority 5); expected it to preempt almost immediately", waitB)
+	}
+}